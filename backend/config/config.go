@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	hub "github.com/phillip/contribution-tracker-go/internal/events/hub"
+)
+
+// DefaultSoftDeleteRetention is how long a soft-deleted event is kept
+// before the janitor purges it, when SoftDeleteRetention isn't set.
+const DefaultSoftDeleteRetention = 30 * 24 * time.Hour
+
+// EventsPushMode selects how event/contribution changes reach SSE
+// subscribers.
+type EventsPushMode string
+
+const (
+	// EventsPushChangeStream watches MongoDB change streams and requires
+	// a replica set (or sharded cluster) deployment.
+	EventsPushChangeStream EventsPushMode = "change_stream"
+	// EventsPushDirect skips change streams entirely and relies on
+	// handlers publishing to the hub directly. Use this against a
+	// standalone MongoDB instance, where change streams aren't available.
+	EventsPushDirect EventsPushMode = "direct"
+)
+
+// Config holds the process-wide dependencies handlers need: the shared
+// Mongo connection, the database name, and feature toggles that vary
+// between deployments.
+type Config struct {
+	MongoClient *mongo.Client
+	DBName      string
+
+	// EventsPushMode controls how the SSE stream endpoint is fed.
+	EventsPushMode EventsPushMode
+	// EventsHub fans out event/contribution updates to SSE subscribers.
+	// It is used directly in EventsPushDirect mode and as the local
+	// fan-out layer behind the change stream in EventsPushChangeStream
+	// mode.
+	EventsHub *hub.Hub
+
+	// SoftDeleteRetention is how long a soft-deleted event is kept
+	// before the janitor permanently purges it. Zero means
+	// DefaultSoftDeleteRetention.
+	SoftDeleteRetention time.Duration
+}
@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	config "github.com/phillip/contribution-tracker-go/config"
+	models "github.com/phillip/contribution-tracker-go/models"
+	repo "github.com/phillip/contribution-tracker-go/repo"
+)
+
+// loadOwnedEvent fetches the event named by the "id" path param and
+// confirms it belongs to userID, writing the error response itself when
+// it doesn't exist or isn't owned. A soft-deleted event is excluded
+// unless the caller passes ?include_deleted=true, matching ListEvents/
+// GetEvent so a deleted event can't keep accepting contributions.
+func loadOwnedEvent(c *gin.Context, ctx context.Context, events *mongo.Collection, userID primitive.ObjectID) (models.Event, bool) {
+	eventID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return models.Event{}, false
+	}
+
+	filter := bson.M{"_id": eventID, "user_id": userID}
+	if c.Query("include_deleted") != "true" {
+		filter["deleted_at"] = bson.M{"$exists": false}
+	}
+
+	var event models.Event
+	if err := events.FindOne(ctx, filter).Decode(&event); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found or not owned"})
+		return models.Event{}, false
+	}
+	return event, true
+}
+
+// ---------------- CREATE CONTRIBUTION ----------------
+// CreateContribution posts a contribution against an event. It requires
+// an Idempotency-Key header; a retried request with a key already seen
+// for this user replays the original response instead of posting twice.
+func CreateContribution(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		idempotencyKey := c.GetHeader("Idempotency-Key")
+		if idempotencyKey == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key header is required"})
+			return
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		idempotency := repo.NewIdempotencyStore(db)
+		reserved, err := idempotency.Reserve(ctx, userID, idempotencyKey)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not check idempotency key"})
+			return
+		}
+		if !reserved {
+			existing, err := idempotency.Get(ctx, userID, idempotencyKey)
+			if err != nil || existing == nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "could not check idempotency key"})
+				return
+			}
+			if existing.Pending() {
+				c.JSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is already in progress"})
+				return
+			}
+			c.Data(existing.StatusCode, "application/json", existing.ResponseBody)
+			return
+		}
+
+		created := false
+		defer func() {
+			if !created {
+				_ = idempotency.Release(ctx, userID, idempotencyKey)
+			}
+		}()
+
+		event, ok := loadOwnedEvent(c, ctx, db.Collection("events"), userID)
+		if !ok {
+			return
+		}
+
+		var input models.Contribution
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if input.Amount <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "amount must be positive"})
+			return
+		}
+
+		contribution := models.Contribution{
+			ID:        primitive.NewObjectID(),
+			EventID:   event.ID,
+			UserID:    userID,
+			Amount:    input.Amount,
+			Note:      input.Note,
+			CreatedAt: time.Now(),
+		}
+
+		contributions := repo.NewContributionsRepo(db)
+		if err := contributions.Create(ctx, contribution); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create contribution"})
+			return
+		}
+		created = true
+
+		publishEventChange(cfg, event, "contribution.added")
+		enqueueWebhookDeliveries(cfg, userID, "contribution.added", contribution)
+		if summary, err := contributions.Summary(ctx, event.ID, userID, event.TargetAmount); err == nil {
+			publish(cfg, event.ID, "progress", summary, "")
+		}
+
+		body, _ := json.Marshal(contribution)
+		_ = idempotency.Complete(ctx, userID, idempotencyKey, http.StatusCreated, body)
+
+		c.Data(http.StatusCreated, "application/json", body)
+	}
+}
+
+// ---------------- LIST CONTRIBUTIONS ----------------
+func ListContributions(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		event, ok := loadOwnedEvent(c, ctx, db.Collection("events"), userID)
+		if !ok {
+			return
+		}
+
+		contributions, err := repo.NewContributionsRepo(db).ListByEvent(ctx, event.ID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch contributions"})
+			return
+		}
+
+		c.JSON(http.StatusOK, contributions)
+	}
+}
+
+// ---------------- DELETE CONTRIBUTION ----------------
+func DeleteContribution(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		event, ok := loadOwnedEvent(c, ctx, db.Collection("events"), userID)
+		if !ok {
+			return
+		}
+
+		contributionID, err := primitive.ObjectIDFromHex(c.Param("contribution_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contribution id"})
+			return
+		}
+
+		deleted, err := repo.NewContributionsRepo(db).Delete(ctx, contributionID, event.ID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not delete contribution"})
+			return
+		}
+		if deleted == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "contribution not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "contribution deleted", "id": contributionID.Hex()})
+	}
+}
+
+// ---------------- SUMMARY ----------------
+// GetEventSummary returns the aggregated contribution progress for an
+// event: total raised, count, percent of target reached, and a weekly
+// histogram.
+func GetEventSummary(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		event, ok := loadOwnedEvent(c, ctx, db.Collection("events"), userID)
+		if !ok {
+			return
+		}
+
+		summary, err := repo.NewContributionsRepo(db).Summary(ctx, event.ID, userID, event.TargetAmount)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not compute summary"})
+			return
+		}
+
+		c.JSON(http.StatusOK, summary)
+	}
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "github.com/phillip/contribution-tracker-go/config"
+	models "github.com/phillip/contribution-tracker-go/models"
+	repo "github.com/phillip/contribution-tracker-go/repo"
+	utils "github.com/phillip/contribution-tracker-go/utils"
+)
+
+// requestID returns the inbound request's tracing id, generating one if
+// upstream middleware didn't set one.
+func requestID(c *gin.Context) string {
+	if id := c.GetString("request_id"); id != "" {
+		return id
+	}
+	return utils.NewUUID()
+}
+
+// recordAudit appends one event_audit entry. Auditing is best-effort: a
+// failure to record it shouldn't fail the mutation that triggered it.
+func recordAudit(cfg *config.Config, c *gin.Context, userID, eventID primitive.ObjectID, action models.EventAuditAction, before, after *models.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry := models.EventAudit{
+		EventID:   eventID,
+		UserID:    userID,
+		Action:    action,
+		Before:    before,
+		After:     after,
+		At:        time.Now(),
+		RequestID: requestID(c),
+	}
+	_ = repo.NewEventAuditRepo(cfg.MongoClient.Database(cfg.DBName)).Record(ctx, entry)
+}
+
+// ---------------- RESTORE ----------------
+// RestoreEvent clears a soft-deleted event's deleted_at/deleted_by so it
+// reappears in ListEvents/GetEvent.
+func RestoreEvent(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		oid, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+			return
+		}
+
+		col := cfg.MongoClient.Database(cfg.DBName).Collection("events")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var before models.Event
+		filter := bson.M{"_id": oid, "user_id": userID, "deleted_at": bson.M{"$exists": true}}
+		if err := col.FindOne(ctx, filter).Decode(&before); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "soft-deleted event not found"})
+			return
+		}
+
+		now := time.Now()
+		_, err = col.UpdateOne(ctx, filter, bson.M{
+			"$set":   bson.M{"updated_at": now},
+			"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore event"})
+			return
+		}
+
+		var after models.Event
+		if err := col.FindOne(ctx, bson.M{"_id": oid, "user_id": userID}).Decode(&after); err == nil {
+			publishEventChange(cfg, after, "event.updated")
+			recordAudit(cfg, c, userID, oid, models.EventAuditRestore, &before, &after)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "event restored", "id": oid.Hex()})
+	}
+}
+
+// ---------------- AUDIT ----------------
+func GetEventAudit(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		eventID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		entries, err := repo.NewEventAuditRepo(cfg.MongoClient.Database(cfg.DBName)).ListByEvent(ctx, eventID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch audit trail"})
+			return
+		}
+
+		c.JSON(http.StatusOK, entries)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	config "github.com/phillip/contribution-tracker-go/config"
 	models "github.com/phillip/contribution-tracker-go/models"
@@ -53,6 +54,10 @@ func CreateEvent(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		publishEventChange(cfg, event, "event.created")
+		enqueueWebhookDeliveries(cfg, userID, "event.created", event)
+		recordAudit(cfg, c, userID, event.ID, models.EventAuditCreate, nil, &event)
+
 		c.JSON(http.StatusCreated, gin.H{"id": event.ID.Hex(), "message": "event created"})
 	}
 }
@@ -68,54 +73,90 @@ func ListEvents(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		// --- Parse and validate query params ---
+		lq, err := parseListQuery(
+			c.Query("limit"), c.Query("sort"), c.Query("order"), c.Query("status"),
+			c.Query("deadline_before"), c.Query("deadline_after"), c.Query("q"), c.Query("cursor"),
+			c.Query("include_deleted"),
+		)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		col := cfg.MongoClient.Database(cfg.DBName).Collection("events")
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		// --- Build filter ---
-		filter := bson.M{"user_id": userID}
-		if q := c.Query("q"); q != "" {
-			filter["title"] = bson.M{"$regex": q, "$options": "i"}
+		// --- ETag from the user's latest updated_at, without pulling the page ---
+		etagFilter := bson.M{"user_id": userID}
+		if !lq.includeDeleted {
+			etagFilter["deleted_at"] = bson.M{"$exists": false}
+		}
+		var latest models.Event
+		latestErr := col.FindOne(ctx, etagFilter,
+			options.FindOne().SetSort(bson.D{{Key: "updated_at", Value: -1}}),
+		).Decode(&latest)
+		if latestErr == nil {
+			etag := utils.GenerateETag(latest.ID, latest.UpdatedAt)
+			if match := c.GetHeader("If-None-Match"); match != "" && match == etag {
+				c.Status(http.StatusNotModified)
+				return
+			}
+			c.Header("ETag", etag)
+			c.Header("Last-Modified", latest.UpdatedAt.UTC().Format(http.TimeFormat))
 		}
 
-		// --- Fetch data ---
-		cursor, err := col.Find(ctx, filter)
+		// --- Build filter ---
+		filter := lq.buildFilter(userID)
+		totalEstimate, err := col.CountDocuments(ctx, filter)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch events"})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not count events"})
 			return
 		}
-
-		var events []models.Event
-		if err := cursor.All(ctx, &events); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not decode events"})
+		if err := lq.applyCursor(filter); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		if len(events) == 0 {
-			c.JSON(http.StatusOK, []models.Event{})
-			return
+		// --- Fetch one extra row so we know whether a next page exists ---
+		order := 1
+		if !lq.ascending {
+			order = -1
+		}
+		findOpts := options.Find().
+			SetSort(bson.D{{Key: lq.sortField, Value: order}, {Key: "_id", Value: order}}).
+			SetLimit(int64(lq.limit) + 1)
+		if lq.text != "" {
+			findOpts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
 		}
 
-		// --- Pick the most recently updated event ---
-		latest := events[0]
-		for _, ev := range events {
-			if ev.UpdatedAt.After(latest.UpdatedAt) {
-				latest = ev
-			}
+		cur, err := col.Find(ctx, filter, findOpts)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch events"})
+			return
 		}
 
-		// --- Generate ETag from latest event ---
-		etag := utils.GenerateETag(latest.ID, latest.UpdatedAt)
-		if match := c.GetHeader("If-None-Match"); match != "" && match == etag {
-			c.Status(http.StatusNotModified)
+		var events []models.Event
+		if err := cur.All(ctx, &events); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not decode events"})
 			return
 		}
-		c.Header("ETag", etag)
 
-		// --- Add Last-Modified from latest event ---
-		c.Header("Last-Modified", latest.UpdatedAt.UTC().Format(http.TimeFormat))
+		var nextCursor string
+		if len(events) > lq.limit {
+			events = events[:lq.limit]
+			token, err := utils.EncodeCursor(cursorFor(lq.sortField, events[len(events)-1]))
+			if err == nil {
+				nextCursor = token
+			}
+		}
 
-		c.JSON(http.StatusOK, events)
+		c.JSON(http.StatusOK, gin.H{
+			"items":          events,
+			"next_cursor":    nextCursor,
+			"total_estimate": totalEstimate,
+		})
 	}
 }
 
@@ -135,13 +176,18 @@ func GetEvent(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		filter := bson.M{"_id": eventID, "user_id": userID}
+		if c.Query("include_deleted") != "true" {
+			filter["deleted_at"] = bson.M{"$exists": false}
+		}
+
 		var event models.Event
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
 		err = cfg.MongoClient.Database(cfg.DBName).
 			Collection("events").
-			FindOne(ctx, bson.M{"_id": eventID, "user_id": userID}).
+			FindOne(ctx, filter).
 			Decode(&event)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "event not found or not owned"})
@@ -210,16 +256,31 @@ func UpdateEvent(cfg *config.Config) gin.HandlerFunc {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		res, err := col.UpdateOne(ctx, bson.M{"_id": oid, "user_id": userID}, bson.M{"$set": update})
+		current, ok := checkPrecondition(c, col, ctx, oid, userID)
+		if !ok {
+			return
+		}
+
+		res, err := col.UpdateOne(ctx, casFilter(c, oid, userID, current.UpdatedAt), bson.M{"$set": update})
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update event"})
 			return
 		}
 		if res.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "event not found or not owned"})
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "event was modified concurrently"})
 			return
 		}
 
+		var updated models.Event
+		if err := col.FindOne(ctx, bson.M{"_id": oid, "user_id": userID}).Decode(&updated); err == nil {
+			publishEventChange(cfg, updated, "event.updated")
+			enqueueWebhookDeliveries(cfg, userID, "event.updated", updated)
+			if input.Status == "CLOSED" {
+				enqueueWebhookDeliveries(cfg, userID, "event.closed", updated)
+			}
+			recordAudit(cfg, c, userID, oid, models.EventAuditUpdate, &current, &updated)
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "event updated", "id": oid.Hex()})
 	}
 }
@@ -244,16 +305,31 @@ func DeleteEvent(cfg *config.Config) gin.HandlerFunc {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		res, err := col.DeleteOne(ctx, bson.M{"_id": oid, "user_id": userID})
+		current, ok := checkPrecondition(c, col, ctx, oid, userID)
+		if !ok {
+			return
+		}
+
+		now := time.Now()
+		res, err := col.UpdateOne(ctx, casFilter(c, oid, userID, current.UpdatedAt), bson.M{
+			"$set": bson.M{"updated_at": now, "deleted_at": now, "deleted_by": userID},
+		})
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete event"})
 			return
 		}
-		if res.DeletedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "event not found or not owned"})
+		if res.MatchedCount == 0 {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "event was modified concurrently"})
 			return
 		}
 
+		deleted := current
+		deleted.DeletedAt = &now
+		deleted.DeletedBy = &userID
+
+		publishEventChange(cfg, deleted, "event.deleted")
+		recordAudit(cfg, c, userID, oid, models.EventAuditDelete, &current, &deleted)
+
 		c.JSON(http.StatusOK, gin.H{"message": "event deleted", "id": oid.Hex()})
 	}
 }
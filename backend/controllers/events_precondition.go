@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+	utils "github.com/phillip/contribution-tracker-go/utils"
+)
+
+// forceRole is the role required to bypass If-Match with ?force=true.
+const forceRole = "admin"
+
+// checkPrecondition enforces RFC 7232 If-Match semantics ahead of a
+// mutation: it loads the current event, and unless the caller both
+// passed ?force=true and holds forceRole, requires If-Match to name the
+// event's current ETag. It writes the appropriate error response itself
+// and returns ok=false when the caller should stop.
+func checkPrecondition(c *gin.Context, col *mongo.Collection, ctx context.Context, oid, userID primitive.ObjectID) (event models.Event, ok bool) {
+	filter := bson.M{"_id": oid, "user_id": userID, "deleted_at": bson.M{"$exists": false}}
+	if err := col.FindOne(ctx, filter).Decode(&event); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found or not owned"})
+		return event, false
+	}
+
+	if c.Query("force") == "true" {
+		if c.GetString("role") != forceRole {
+			c.JSON(http.StatusForbidden, gin.H{"error": "force requires " + forceRole + " role"})
+			return event, false
+		}
+		return event, true
+	}
+
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return event, false
+	}
+
+	if ifMatch != utils.GenerateETag(event.ID, event.UpdatedAt) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "event has been modified since If-Match was read"})
+		return event, false
+	}
+
+	return event, true
+}
+
+// casFilter returns the atomic compare-and-swap filter for the mutation
+// itself: matching on the updated_at read during checkPrecondition
+// closes the race window between that read and the write. Under
+// ?force=true there's nothing to compare against, so the filter falls
+// back to ownership alone.
+func casFilter(c *gin.Context, oid, userID primitive.ObjectID, seenUpdatedAt time.Time) bson.M {
+	filter := bson.M{"_id": oid, "user_id": userID}
+	if c.Query("force") != "true" {
+		filter["updated_at"] = seenUpdatedAt
+	}
+	return filter
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	utils "github.com/phillip/contribution-tracker-go/utils"
+)
+
+func newPreconditionTestContext(method string, headers, query map[string]string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	req := httptest.NewRequest(method, "/events/x", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	q := req.URL.Query()
+	for k, v := range query {
+		q.Set(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+	c.Request = req
+	return c, w
+}
+
+func TestCasFilter(t *testing.T) {
+	oid := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	seenUpdatedAt := time.Now()
+
+	c, _ := newPreconditionTestContext(http.MethodPut, nil, nil)
+	filter := casFilter(c, oid, userID, seenUpdatedAt)
+	if filter["updated_at"] != seenUpdatedAt {
+		t.Fatalf("expected filter to bound on seenUpdatedAt, got %v", filter)
+	}
+
+	forced, _ := newPreconditionTestContext(http.MethodPut, nil, map[string]string{"force": "true"})
+	filter = casFilter(forced, oid, userID, seenUpdatedAt)
+	if _, ok := filter["updated_at"]; ok {
+		t.Fatalf("expected ?force=true to drop the updated_at bound, got %v", filter)
+	}
+	if filter["_id"] != oid || filter["user_id"] != userID {
+		t.Fatalf("expected ownership filter to remain under force, got %v", filter)
+	}
+}
+
+// TestCheckPrecondition exercises checkPrecondition against a mocked
+// Mongo collection (no real mongod required) for each branch of RFC
+// 7232 enforcement.
+func TestCheckPrecondition(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	eventDoc := func(oid, userID primitive.ObjectID, updatedAt time.Time) bson.D {
+		return bson.D{
+			{Key: "_id", Value: oid},
+			{Key: "user_id", Value: userID},
+			{Key: "updated_at", Value: updatedAt},
+		}
+	}
+
+	mt.Run("missing If-Match returns 428", func(mt *mtest.T) {
+		oid, userID, now := primitive.NewObjectID(), primitive.NewObjectID(), time.Now()
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, eventDoc(oid, userID, now)))
+
+		c, w := newPreconditionTestContext(http.MethodPut, nil, nil)
+		if _, ok := checkPrecondition(c, mt.Coll, context.Background(), oid, userID); ok {
+			t.Fatalf("expected ok=false without If-Match")
+		}
+		if w.Code != http.StatusPreconditionRequired {
+			t.Fatalf("expected %d, got %d", http.StatusPreconditionRequired, w.Code)
+		}
+	})
+
+	mt.Run("stale If-Match returns 412", func(mt *mtest.T) {
+		oid, userID, now := primitive.NewObjectID(), primitive.NewObjectID(), time.Now()
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, eventDoc(oid, userID, now)))
+
+		c, w := newPreconditionTestContext(http.MethodPut, map[string]string{"If-Match": `"stale"`}, nil)
+		if _, ok := checkPrecondition(c, mt.Coll, context.Background(), oid, userID); ok {
+			t.Fatalf("expected ok=false for a stale If-Match")
+		}
+		if w.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected %d, got %d", http.StatusPreconditionFailed, w.Code)
+		}
+	})
+
+	mt.Run("matching If-Match succeeds", func(mt *mtest.T) {
+		oid, userID, now := primitive.NewObjectID(), primitive.NewObjectID(), time.Now()
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, eventDoc(oid, userID, now)))
+
+		etag := utils.GenerateETag(oid, now)
+		c, _ := newPreconditionTestContext(http.MethodPut, map[string]string{"If-Match": etag}, nil)
+		if _, ok := checkPrecondition(c, mt.Coll, context.Background(), oid, userID); !ok {
+			t.Fatalf("expected ok=true for a matching If-Match")
+		}
+	})
+
+	mt.Run("force without the required role is forbidden", func(mt *mtest.T) {
+		oid, userID, now := primitive.NewObjectID(), primitive.NewObjectID(), time.Now()
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, eventDoc(oid, userID, now)))
+
+		c, w := newPreconditionTestContext(http.MethodPut, nil, map[string]string{"force": "true"})
+		if _, ok := checkPrecondition(c, mt.Coll, context.Background(), oid, userID); ok {
+			t.Fatalf("expected ok=false when forcing without %s role", forceRole)
+		}
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected %d, got %d", http.StatusForbidden, w.Code)
+		}
+	})
+
+	mt.Run("force with the required role bypasses If-Match", func(mt *mtest.T) {
+		oid, userID, now := primitive.NewObjectID(), primitive.NewObjectID(), time.Now()
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, eventDoc(oid, userID, now)))
+
+		c, _ := newPreconditionTestContext(http.MethodPut, nil, map[string]string{"force": "true"})
+		c.Set("role", forceRole)
+		if _, ok := checkPrecondition(c, mt.Coll, context.Background(), oid, userID); !ok {
+			t.Fatalf("expected ok=true when forcing with %s role", forceRole)
+		}
+	})
+}
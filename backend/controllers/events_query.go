@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+	utils "github.com/phillip/contribution-tracker-go/utils"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// listSortFields maps the `?sort=` query value to its bson field name.
+// Keeping this as an allowlist means a caller can never sneak an
+// unintended field into the sort. Only updated_at has a supporting
+// index (user_id+updated_at); deadline and target_amount sorts are
+// unindexed.
+var listSortFields = map[string]string{
+	"updated_at":    "updated_at",
+	"deadline":      "deadline",
+	"target_amount": "target_amount",
+}
+
+// listQuery is the parsed, validated form of ListEvents' query string.
+type listQuery struct {
+	limit          int
+	sortField      string
+	ascending      bool
+	statuses       []string
+	deadlineBefore *time.Time
+	deadlineAfter  *time.Time
+	text           string
+	cursor         *utils.Cursor
+	includeDeleted bool
+}
+
+func parseListQuery(limitParam, sortParam, orderParam, statusParam, beforeParam, afterParam, qParam, cursorParam, includeDeletedParam string) (listQuery, error) {
+	q := listQuery{limit: defaultListLimit, sortField: "updated_at", ascending: false, includeDeleted: includeDeletedParam == "true"}
+
+	if limitParam != "" {
+		n, err := strconv.Atoi(limitParam)
+		if err != nil || n <= 0 {
+			return q, fmt.Errorf("invalid limit")
+		}
+		if n > maxListLimit {
+			n = maxListLimit
+		}
+		q.limit = n
+	}
+
+	if sortParam != "" {
+		field, ok := listSortFields[sortParam]
+		if !ok {
+			return q, fmt.Errorf("invalid sort field %q", sortParam)
+		}
+		q.sortField = field
+	}
+
+	switch orderParam {
+	case "", "desc":
+		q.ascending = false
+	case "asc":
+		q.ascending = true
+	default:
+		return q, fmt.Errorf("invalid order %q", orderParam)
+	}
+
+	if statusParam != "" {
+		for _, s := range strings.Split(statusParam, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				q.statuses = append(q.statuses, s)
+			}
+		}
+	}
+
+	if beforeParam != "" {
+		t, err := time.Parse(time.RFC3339, beforeParam)
+		if err != nil {
+			return q, fmt.Errorf("invalid deadline_before")
+		}
+		q.deadlineBefore = &t
+	}
+	if afterParam != "" {
+		t, err := time.Parse(time.RFC3339, afterParam)
+		if err != nil {
+			return q, fmt.Errorf("invalid deadline_after")
+		}
+		q.deadlineAfter = &t
+	}
+
+	q.text = strings.TrimSpace(qParam)
+
+	if cursorParam != "" {
+		c, err := utils.DecodeCursor(cursorParam)
+		if err != nil {
+			return q, err
+		}
+		q.cursor = &c
+	}
+
+	return q, nil
+}
+
+// buildFilter assembles the mongo filter for a user's events from a
+// parsed listQuery, excluding the keyset cursor bound (applied
+// separately since it depends on sort direction).
+func (q listQuery) buildFilter(userID primitive.ObjectID) bson.M {
+	filter := bson.M{"user_id": userID}
+
+	if !q.includeDeleted {
+		filter["deleted_at"] = bson.M{"$exists": false}
+	}
+
+	if len(q.statuses) > 0 {
+		filter["status"] = bson.M{"$in": q.statuses}
+	}
+
+	if q.deadlineBefore != nil || q.deadlineAfter != nil {
+		deadline := bson.M{}
+		if q.deadlineAfter != nil {
+			deadline["$gte"] = *q.deadlineAfter
+		}
+		if q.deadlineBefore != nil {
+			deadline["$lte"] = *q.deadlineBefore
+		}
+		filter["deadline"] = deadline
+	}
+
+	if q.text != "" {
+		filter["$text"] = bson.M{"$search": q.text}
+	}
+
+	return filter
+}
+
+// applyCursor adds the keyset pagination bound to filter in place. The
+// comparison operator flips with sort direction: paging forward through
+// a descending sort means "strictly less than the last value seen".
+func (q listQuery) applyCursor(filter bson.M) error {
+	if q.cursor == nil {
+		return nil
+	}
+
+	value, err := decodeSortValue(q.sortField, q.cursor.SortValue)
+	if err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	op := "$lt"
+	if q.ascending {
+		op = "$gt"
+	}
+
+	filter["$or"] = []bson.M{
+		{q.sortField: bson.M{op: value}},
+		{
+			q.sortField: value,
+			"_id":       bson.M{op: q.cursor.ID},
+		},
+	}
+	return nil
+}
+
+// noDeadlineSortValue is the cursor encoding for a nil Deadline: it
+// can't be formatted as RFC3339Nano like a real deadline, so it needs
+// its own sentinel decodeSortValue recognizes rather than encoding as an
+// empty string that time.Parse would reject as an invalid cursor.
+const noDeadlineSortValue = "-"
+
+// decodeSortValue parses the opaque string a Cursor carries back into
+// the field's native type, since bson.M comparisons need a time.Time or
+// float64 rather than the string form utils.Cursor stores it as.
+func decodeSortValue(sortField, raw string) (interface{}, error) {
+	switch sortField {
+	case "target_amount":
+		return strconv.ParseFloat(raw, 64)
+	case "deadline":
+		if raw == noDeadlineSortValue {
+			return nil, nil
+		}
+		return time.Parse(time.RFC3339Nano, raw)
+	default: // updated_at
+		return time.Parse(time.RFC3339Nano, raw)
+	}
+}
+
+// cursorFor builds the keyset cursor pointing just past event, given the
+// field the page was sorted on.
+func cursorFor(sortField string, event models.Event) utils.Cursor {
+	var value string
+	switch sortField {
+	case "target_amount":
+		value = strconv.FormatFloat(event.TargetAmount, 'f', -1, 64)
+	case "deadline":
+		if event.Deadline != nil {
+			value = event.Deadline.Format(time.RFC3339Nano)
+		} else {
+			value = noDeadlineSortValue
+		}
+	default: // updated_at
+		value = event.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return utils.Cursor{SortValue: value, ID: event.ID}
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+)
+
+func TestCursorForAndDecodeSortValueRoundTrip(t *testing.T) {
+	deadline := time.Date(2026, time.August, 1, 0, 0, 0, 0, time.UTC)
+	event := models.Event{ID: primitive.NewObjectID(), Deadline: &deadline, TargetAmount: 250.5, UpdatedAt: time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)}
+
+	for _, sortField := range []string{"updated_at", "deadline", "target_amount"} {
+		cursor := cursorFor(sortField, event)
+		value, err := decodeSortValue(sortField, cursor.SortValue)
+		if err != nil {
+			t.Fatalf("sort field %q: decodeSortValue(%q) returned error: %v", sortField, cursor.SortValue, err)
+		}
+		switch sortField {
+		case "updated_at":
+			if !value.(time.Time).Equal(event.UpdatedAt) {
+				t.Fatalf("sort field %q: expected %v, got %v", sortField, event.UpdatedAt, value)
+			}
+		case "deadline":
+			if !value.(time.Time).Equal(deadline) {
+				t.Fatalf("sort field %q: expected %v, got %v", sortField, deadline, value)
+			}
+		case "target_amount":
+			if value.(float64) != event.TargetAmount {
+				t.Fatalf("sort field %q: expected %v, got %v", sortField, event.TargetAmount, value)
+			}
+		}
+	}
+}
+
+func TestCursorForNilDeadlineDecodesWithoutError(t *testing.T) {
+	event := models.Event{ID: primitive.NewObjectID(), Deadline: nil}
+
+	cursor := cursorFor("deadline", event)
+	if cursor.SortValue == "" {
+		t.Fatalf("expected a non-empty sentinel for a nil deadline, got an empty SortValue that time.Parse would reject")
+	}
+
+	value, err := decodeSortValue("deadline", cursor.SortValue)
+	if err != nil {
+		t.Fatalf("expected a nil deadline's cursor to decode cleanly, got error: %v", err)
+	}
+	if value != nil {
+		t.Fatalf("expected decodeSortValue to return a nil comparison value for a missing deadline, got %v", value)
+	}
+}
+
+func TestParseListQueryInvalidCursorIsRejected(t *testing.T) {
+	if _, err := parseListQuery("", "", "", "", "", "", "", "not-a-valid-cursor", ""); err == nil {
+		t.Fatalf("expected an invalid cursor token to be rejected")
+	}
+}
@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	config "github.com/phillip/contribution-tracker-go/config"
+	hub "github.com/phillip/contribution-tracker-go/internal/events/hub"
+	models "github.com/phillip/contribution-tracker-go/models"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+// watcherMu guards watchers, the single change-stream watcher goroutine
+// running per eventID, shared across however many tabs are subscribed to
+// it. It also serializes every subscribe/unsubscribe against the
+// start/stop decision that follows it, so a subscriber racing in just as
+// the last one leaves can never overwrite the watcher it's about to
+// start with the outgoing subscriber's stop (or vice versa).
+var (
+	watcherMu sync.Mutex
+	watchers  = make(map[string]context.CancelFunc)
+)
+
+// subscribeEvent subscribes to eventID's hub messages and, in change
+// stream mode, starts its watcher if this is the first subscriber. The
+// returned cleanup unsubscribes and stops the watcher once this is the
+// last subscriber to leave.
+func subscribeEvent(cfg *config.Config, eventID, userID primitive.ObjectID, lastEventID string) (msgCh <-chan hub.Message, cleanup func()) {
+	watcherMu.Lock()
+	ch, unsubscribe, first := cfg.EventsHub.Subscribe(eventID.Hex())
+	if first && cfg.EventsPushMode == config.EventsPushChangeStream {
+		ctx, cancel := context.WithCancel(context.Background())
+		watchers[eventID.Hex()] = cancel
+		go watchEventChanges(ctx, cfg, eventID, userID, lastEventID)
+	}
+	watcherMu.Unlock()
+
+	cleanup = func() {
+		watcherMu.Lock()
+		last := unsubscribe()
+		var cancel context.CancelFunc
+		if last {
+			cancel = watchers[eventID.Hex()]
+			delete(watchers, eventID.Hex())
+		}
+		watcherMu.Unlock()
+		if cancel != nil {
+			cancel()
+		}
+	}
+	return ch, cleanup
+}
+
+// ---------------- STREAM ----------------
+// StreamEvent upgrades the connection to Server-Sent Events and pushes
+// event.updated, contribution.added, and progress messages for a single
+// event as they happen. In change-stream mode a background watcher feeds
+// the hub; in direct mode CreateEvent/UpdateEvent/DeleteEvent publish to
+// the hub themselves, which also serves as the fallback used against a
+// standalone MongoDB deployment without a replica set.
+func StreamEvent(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		eventID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+			return
+		}
+
+		col := cfg.MongoClient.Database(cfg.DBName).Collection("events")
+		checkCtx, cancelCheck := context.WithTimeout(context.Background(), 5*time.Second)
+		count, err := col.CountDocuments(checkCtx, bson.M{"_id": eventID, "user_id": userID})
+		cancelCheck()
+		if err != nil || count == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "event not found or not owned"})
+			return
+		}
+
+		streamCtx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		msgCh, cleanup := subscribeEvent(cfg, eventID, userID, c.GetHeader("Last-Event-ID"))
+		defer cleanup()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-streamCtx.Done():
+				return false
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+				return true
+			case msg, ok := <-msgCh:
+				if !ok {
+					return false
+				}
+				if msg.ID != "" {
+					fmt.Fprintf(w, "id: %s\n", msg.ID)
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Event, msg.Data)
+				return true
+			}
+		})
+	}
+}
+
+// watchEventChanges tails a MongoDB change stream for a single event and
+// relays matching changes into the hub, so every subscriber tab shares
+// one upstream watcher instead of opening its own. It exits silently
+// when change streams aren't supported (e.g. a standalone deployment);
+// UpdateEvent/CreateEvent/DeleteEvent's direct publishes are the fallback
+// for that case.
+//
+// The $match only filters on documentKey._id: delete operations never
+// populate fullDocument (options.UpdateLookup back-fills it for updates
+// only), so a fullDocument.user_id match would silently drop every
+// delete. Ownership is instead checked after decode, against the
+// fullDocument when one is present.
+func watchEventChanges(ctx context.Context, cfg *config.Config, eventID, userID primitive.ObjectID, lastEventID string) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{
+			{Key: "documentKey._id", Value: eventID},
+		}}},
+	}
+
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if lastEventID != "" {
+		var resumeToken bson.Raw
+		if err := bson.UnmarshalExtJSON([]byte(lastEventID), false, &resumeToken); err == nil {
+			opts.SetResumeAfter(resumeToken)
+		}
+	}
+
+	col := cfg.MongoClient.Database(cfg.DBName).Collection("events")
+	stream, err := col.Watch(ctx, pipeline, opts)
+	if err != nil {
+		// Standalone MongoDB (no replica set) doesn't support change
+		// streams; handlers' direct publishes cover this case instead.
+		return
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var changed struct {
+			OperationType string       `bson:"operationType"`
+			FullDocument  models.Event `bson:"fullDocument"`
+		}
+		if err := stream.Decode(&changed); err != nil {
+			continue
+		}
+		if changed.OperationType != "delete" && changed.FullDocument.UserID != userID {
+			continue
+		}
+
+		eventName := "event.updated"
+		if changed.OperationType == "delete" {
+			eventName = "event.deleted"
+		}
+
+		resumeToken, _ := bson.MarshalExtJSON(stream.ResumeToken(), false, false)
+		publish(cfg, eventID, eventName, changed.FullDocument, string(resumeToken))
+	}
+}
+
+// publishEventChange publishes directly to the hub. The event handlers
+// (create, update, delete, restore) and CreateContribution call it in
+// every EventsPushMode. In EventsPushDirect mode it's the only source of
+// updates. In change-stream mode watchEventChanges also emits event
+// writes (a soft delete arrives from it as "event.updated"), while
+// contribution.added messages come only from this direct publish, since
+// the watcher tails the events collection alone.
+func publishEventChange(cfg *config.Config, event models.Event, eventName string) {
+	if cfg == nil || cfg.EventsHub == nil {
+		return
+	}
+	publish(cfg, event.ID, eventName, event, "")
+}
+
+func publish(cfg *config.Config, eventID primitive.ObjectID, eventName string, payload interface{}, resumeID string) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	cfg.EventsHub.Publish(eventID.Hex(), hub.Message{
+		Event: eventName,
+		ID:    resumeID,
+		Data:  data,
+	})
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "github.com/phillip/contribution-tracker-go/config"
+	models "github.com/phillip/contribution-tracker-go/models"
+	repo "github.com/phillip/contribution-tracker-go/repo"
+)
+
+// webhookEvents is the allowlist of event names a webhook may subscribe to.
+var webhookEvents = map[string]bool{
+	"event.created":      true,
+	"event.updated":      true,
+	"event.closed":       true,
+	"contribution.added": true,
+}
+
+// ---------------- CREATE WEBHOOK ----------------
+func CreateWebhook(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		var input struct {
+			URL    string   `json:"url" binding:"required"`
+			Secret string   `json:"secret" binding:"required"`
+			Events []string `json:"events" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		for _, e := range input.Events {
+			if !webhookEvents[e] {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "unknown event type: " + e})
+				return
+			}
+		}
+
+		webhook := models.WebhookEndpoint{
+			ID:        primitive.NewObjectID(),
+			UserID:    userID,
+			URL:       input.URL,
+			Secret:    input.Secret,
+			Events:    input.Events,
+			CreatedAt: time.Now(),
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := repo.NewWebhooksRepo(db).Create(ctx, webhook); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create webhook"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"id": webhook.ID.Hex(), "message": "webhook created"})
+	}
+}
+
+// ---------------- LIST DELIVERIES ----------------
+func ListWebhookDeliveries(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		webhookID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook id"})
+			return
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if _, err := repo.NewWebhooksRepo(db).Get(ctx, webhookID, userID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found or not owned"})
+			return
+		}
+
+		deliveries, err := repo.NewDeliveriesRepo(db).ListByWebhook(ctx, webhookID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch deliveries"})
+			return
+		}
+
+		c.JSON(http.StatusOK, deliveries)
+	}
+}
+
+// ---------------- REDELIVER ----------------
+// RedeliverDelivery resets a delivery to pending so the dispatcher's
+// worker pool picks it up again on its next poll, regardless of how it
+// previously resolved.
+func RedeliverDelivery(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		userID, err := primitive.ObjectIDFromHex(uid)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		deliveryID, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+			return
+		}
+
+		db := cfg.MongoClient.Database(cfg.DBName)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		modified, err := repo.NewDeliveriesRepo(db).Redeliver(ctx, deliveryID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not redeliver"})
+			return
+		}
+		if modified == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "delivery not found or not owned"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "delivery requeued", "id": deliveryID.Hex()})
+	}
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	config "github.com/phillip/contribution-tracker-go/config"
+	models "github.com/phillip/contribution-tracker-go/models"
+	repo "github.com/phillip/contribution-tracker-go/repo"
+)
+
+// enqueueWebhookDeliveries queues one pending delivery per webhook the
+// user has registered for eventName. It's called from the handlers that
+// change event/contribution state; the dispatcher's worker pool picks
+// deliveries up on its next poll.
+func enqueueWebhookDeliveries(cfg *config.Config, userID primitive.ObjectID, eventName string, payload interface{}) {
+	db := cfg.MongoClient.Database(cfg.DBName)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhooks, err := repo.NewWebhooksRepo(db).ListSubscribers(ctx, userID, eventName)
+	if err != nil || len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	deliveries := repo.NewDeliveriesRepo(db)
+	for _, webhook := range webhooks {
+		_ = deliveries.Enqueue(ctx, newPendingDelivery(webhook.ID, userID, eventName, body))
+	}
+}
+
+func newPendingDelivery(webhookID, userID primitive.ObjectID, eventName string, payload []byte) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: webhookID,
+		UserID:    userID,
+		Event:     eventName,
+		Payload:   payload,
+		Status:    models.WebhookDeliveryPending,
+		CreatedAt: time.Now(),
+	}
+}
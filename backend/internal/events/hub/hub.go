@@ -0,0 +1,83 @@
+// Package hub implements a small in-process pub/sub fan-out so that many
+// SSE subscribers watching the same event can share a single upstream
+// source (a MongoDB change stream, or a direct publish from a handler)
+// instead of each opening their own.
+package hub
+
+import "sync"
+
+// Message is one unit of fan-out delivered to subscribers of an event.
+type Message struct {
+	// Event is the SSE event name, e.g. "event.updated", "contribution.added", "progress".
+	Event string
+	// ID is used as the SSE id: field so clients can resume via Last-Event-ID.
+	// For change-stream-backed publishes this is the resume token; for
+	// fallback publishes it is empty and resume is best-effort.
+	ID string
+	// Data is the JSON-encoded payload.
+	Data []byte
+}
+
+type subscriber chan Message
+
+// Hub fans messages out to subscribers grouped by event ID.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[subscriber]struct{}
+}
+
+// New returns an empty Hub ready to use.
+func New() *Hub {
+	return &Hub{subs: make(map[string]map[subscriber]struct{})}
+}
+
+// Subscribe registers a new subscriber for eventID and returns a channel
+// of messages, an unsubscribe func that must be called (typically via
+// defer) once the caller stops reading, and first: whether sub is the
+// only subscriber eventID currently has. A caller that owns starting the
+// upstream watcher for eventID (a MongoDB change stream) should only do
+// so when first is true, and should stop it once unsubscribe reports it
+// removed the last remaining subscriber — that keeps exactly one watcher
+// per eventID no matter how many tabs are subscribed.
+func (h *Hub) Subscribe(eventID string) (ch <-chan Message, unsubscribe func() (last bool), first bool) {
+	sub := make(subscriber, 16)
+
+	h.mu.Lock()
+	if h.subs[eventID] == nil {
+		h.subs[eventID] = make(map[subscriber]struct{})
+	}
+	first = len(h.subs[eventID]) == 0
+	h.subs[eventID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe = func() (last bool) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if set, ok := h.subs[eventID]; ok {
+			delete(set, sub)
+			if len(set) == 0 {
+				delete(h.subs, eventID)
+				last = true
+			}
+		}
+		close(sub)
+		return last
+	}
+
+	return sub, unsubscribe, first
+}
+
+// Publish fans msg out to every current subscriber of eventID. It never
+// blocks on a slow subscriber; a subscriber that can't keep up drops the
+// message rather than stall the publisher.
+func (h *Hub) Publish(eventID string, msg Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[eventID] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
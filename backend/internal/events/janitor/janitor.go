@@ -0,0 +1,54 @@
+// Package janitor permanently purges events that have been soft-deleted
+// for longer than their retention window.
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	config "github.com/phillip/contribution-tracker-go/config"
+)
+
+const sweepInterval = 1 * time.Hour
+
+// Janitor periodically deletes events whose deleted_at is older than
+// retention. Construct one with New and run it from its own goroutine
+// at startup; Run blocks until ctx is cancelled.
+type Janitor struct {
+	events    *mongo.Collection
+	retention time.Duration
+}
+
+// New returns a Janitor backed by db's "events" collection, purging
+// soft-deleted events older than retention. A zero retention falls back
+// to config.DefaultSoftDeleteRetention.
+func New(db *mongo.Database, retention time.Duration) *Janitor {
+	if retention <= 0 {
+		retention = config.DefaultSoftDeleteRetention
+	}
+	return &Janitor{events: db.Collection("events"), retention: retention}
+}
+
+// Run sweeps for purge candidates every sweepInterval until ctx is cancelled.
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		j.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *Janitor) sweep(ctx context.Context) {
+	cutoff := time.Now().Add(-j.retention)
+	_, _ = j.events.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lte": cutoff}})
+}
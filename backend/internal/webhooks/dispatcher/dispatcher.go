@@ -0,0 +1,169 @@
+// Package dispatcher runs the background worker pool that delivers
+// queued webhook notifications: it pulls due deliveries from the
+// webhook_deliveries collection, POSTs the signed payload, and retries
+// with exponential backoff and jitter until it succeeds or exhausts its
+// attempt budget.
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+	repo "github.com/phillip/contribution-tracker-go/repo"
+	utils "github.com/phillip/contribution-tracker-go/utils"
+)
+
+const (
+	defaultWorkers     = 4
+	defaultMaxAttempts = 6
+	defaultBaseBackoff = 5 * time.Second
+	defaultMaxBackoff  = 15 * time.Minute
+	defaultLeaseFor    = 30 * time.Second
+	pollIdleDelay      = 2 * time.Second
+	responseSnippetLen = 500
+)
+
+// Dispatcher owns the worker pool. Construct one with New and call Run
+// from its own goroutine (or several, once per process) at startup;
+// Run blocks until ctx is cancelled.
+type Dispatcher struct {
+	deliveries  *repo.DeliveriesRepo
+	webhooks    *repo.WebhooksRepo
+	httpClient  *http.Client
+	workers     int
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	leaseFor    time.Duration
+}
+
+// New returns a Dispatcher backed by db, with repo-recommended defaults
+// for worker count, retry budget, and backoff.
+func New(db *mongo.Database) *Dispatcher {
+	return &Dispatcher{
+		deliveries:  repo.NewDeliveriesRepo(db),
+		webhooks:    repo.NewWebhooksRepo(db),
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		workers:     defaultWorkers,
+		maxAttempts: defaultMaxAttempts,
+		baseBackoff: defaultBaseBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		leaseFor:    defaultLeaseFor,
+	}
+}
+
+// Run starts the worker pool and blocks until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	done := make(chan struct{}, d.workers)
+	for i := 0; i < d.workers; i++ {
+		go func() {
+			d.worker(ctx)
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < d.workers; i++ {
+		<-done
+	}
+}
+
+func (d *Dispatcher) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		delivery, found, err := d.deliveries.ClaimNext(ctx, d.leaseFor)
+		if err != nil || !found {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollIdleDelay):
+			}
+			continue
+		}
+
+		d.deliver(ctx, delivery)
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery models.WebhookDelivery) {
+	webhook, err := d.webhooks.Get(ctx, delivery.WebhookID, delivery.UserID)
+	if err != nil {
+		// The endpoint was deleted after this delivery was queued; nothing to retry.
+		_ = d.deliveries.RecordAttempt(ctx, delivery.ID,
+			models.WebhookDeliveryAttempt{Attempt: len(delivery.Attempts) + 1, Error: "webhook endpoint no longer exists", At: time.Now()},
+			false, nil)
+		return
+	}
+
+	attemptNum := len(delivery.Attempts) + 1
+	statusCode, snippet, sendErr := d.send(ctx, webhook, delivery)
+
+	succeeded := sendErr == nil && statusCode >= 200 && statusCode < 300
+	attempt := models.WebhookDeliveryAttempt{
+		Attempt:         attemptNum,
+		StatusCode:      statusCode,
+		ResponseSnippet: snippet,
+		At:              time.Now(),
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+
+	var nextRetryAt *time.Time
+	if !succeeded && attemptNum < d.maxAttempts {
+		next := time.Now().Add(d.backoff(attemptNum))
+		nextRetryAt = &next
+	}
+
+	_ = d.deliveries.RecordAttempt(ctx, delivery.ID, attempt, succeeded, nextRetryAt)
+}
+
+// send POSTs the delivery payload to the webhook's URL, signing the body
+// with HMAC-SHA256 over the endpoint's secret.
+func (d *Dispatcher) send(ctx context.Context, webhook models.WebhookEndpoint, delivery models.WebhookDelivery) (statusCode int, responseSnippet string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Delivery-Id", utils.NewUUID())
+	req.Header.Set("X-Signature", "sha256="+sign(webhook.Secret, delivery.Payload))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseSnippetLen))
+	return resp.StatusCode, string(body), nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns exponential backoff for attemptNum with full jitter,
+// capped at maxBackoff.
+func (d *Dispatcher) backoff(attemptNum int) time.Duration {
+	exp := d.baseBackoff * time.Duration(1<<uint(attemptNum-1))
+	if exp > d.maxBackoff {
+		exp = d.maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(exp) + 1))
+}
@@ -0,0 +1,47 @@
+package dispatcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignIsDeterministicAndKeyed(t *testing.T) {
+	body := []byte(`{"event":"contribution.added"}`)
+
+	sig := sign("secret-a", body)
+	if sig != sign("secret-a", body) {
+		t.Fatalf("expected sign to be deterministic for the same secret and body")
+	}
+	if sig == sign("secret-b", body) {
+		t.Fatalf("expected a different secret to produce a different signature")
+	}
+	if sig == sign("secret-a", []byte(`{"event":"event.updated"}`)) {
+		t.Fatalf("expected a different body to produce a different signature")
+	}
+}
+
+func TestBackoffStaysWithinBounds(t *testing.T) {
+	d := &Dispatcher{baseBackoff: defaultBaseBackoff, maxBackoff: defaultMaxBackoff}
+
+	for attempt := 1; attempt <= defaultMaxAttempts; attempt++ {
+		for i := 0; i < 20; i++ {
+			got := d.backoff(attempt)
+			if got < 0 {
+				t.Fatalf("attempt %d: backoff must never be negative, got %v", attempt, got)
+			}
+			if got > d.maxBackoff {
+				t.Fatalf("attempt %d: backoff %v exceeds maxBackoff %v", attempt, got, d.maxBackoff)
+			}
+		}
+	}
+}
+
+func TestBackoffCapsAtMaxBackoff(t *testing.T) {
+	d := &Dispatcher{baseBackoff: defaultBaseBackoff, maxBackoff: 10 * time.Second}
+
+	for i := 0; i < 20; i++ {
+		if got := d.backoff(10); got > d.maxBackoff {
+			t.Fatalf("expected high attempt numbers to cap at maxBackoff (%v), got %v", d.maxBackoff, got)
+		}
+	}
+}
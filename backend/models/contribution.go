@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Contribution is a single pledge or payment posted against an event by
+// its owner.
+type Contribution struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	EventID   primitive.ObjectID `bson:"event_id" json:"event_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Amount    float64            `bson:"amount" json:"amount"`
+	Note      string             `bson:"note,omitempty" json:"note,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// EventSummary is the aggregated view of an event's contributions
+// returned by GET /events/:id/summary.
+type EventSummary struct {
+	Total           float64        `json:"total"`
+	Count           int64          `json:"count"`
+	PercentComplete float64        `json:"percent_complete"`
+	Weekly          []WeeklyBucket `json:"weekly"`
+}
+
+// WeeklyBucket is one $bucket histogram entry: the total contributed in
+// the week starting WeekStart.
+type WeeklyBucket struct {
+	WeekStart time.Time `json:"week_start"`
+	Total     float64   `json:"total"`
+	Count     int64     `json:"count"`
+}
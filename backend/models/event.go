@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Event represents a fundraising or contribution-tracking event owned by a user.
+type Event struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Title        string             `bson:"title" json:"title"`
+	Description  string             `bson:"description" json:"description"`
+	Location     string             `bson:"location" json:"location"`
+	TargetAmount float64            `bson:"target_amount" json:"target_amount"`
+	Deadline     *time.Time         `bson:"deadline,omitempty" json:"deadline,omitempty"`
+	Status       string             `bson:"status" json:"status"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+
+	// DeletedAt/DeletedBy mark a soft-deleted event. Both are nil for a
+	// live event; ListEvents/GetEvent exclude soft-deleted events unless
+	// ?include_deleted=true is passed.
+	DeletedAt *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy *primitive.ObjectID `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventAuditAction identifies what happened to an event in one audit entry.
+type EventAuditAction string
+
+const (
+	EventAuditCreate  EventAuditAction = "create"
+	EventAuditUpdate  EventAuditAction = "update"
+	EventAuditDelete  EventAuditAction = "delete"
+	EventAuditRestore EventAuditAction = "restore"
+)
+
+// EventAudit is one append-only record of a change made to an event.
+// Before is nil for a create. Since events are soft-deleted rather than
+// removed outright, After is never nil for a delete either — it holds
+// the post-delete snapshot, DeletedAt/DeletedBy included.
+type EventAudit struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	EventID   primitive.ObjectID `bson:"event_id" json:"event_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Action    EventAuditAction   `bson:"action" json:"action"`
+	Before    *Event             `bson:"before,omitempty" json:"before,omitempty"`
+	After     *Event             `bson:"after,omitempty" json:"after,omitempty"`
+	At        time.Time          `bson:"at" json:"at"`
+	RequestID string             `bson:"request_id" json:"request_id"`
+}
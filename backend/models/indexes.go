@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes the events, contributions,
+// idempotency_keys, and webhook collections rely on. It's idempotent
+// (CreateMany is a no-op for indexes that already exist with the same
+// spec) so it's safe to call on every startup.
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	if err := ensureEventIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureContributionIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureIdempotencyIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureWebhookIndexes(ctx, db); err != nil {
+		return err
+	}
+	return ensureAuditIndexes(ctx, db)
+}
+
+func ensureEventIndexes(ctx context.Context, db *mongo.Database) error {
+	events := db.Collection("events")
+
+	_, err := events.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().SetName("events_text_search"),
+		},
+		{
+			// Scanned by the retention janitor to find purge candidates
+			// across all users.
+			Keys: bson.D{{Key: "deleted_at", Value: 1}},
+		},
+	})
+	return err
+}
+
+func ensureContributionIndexes(ctx context.Context, db *mongo.Database) error {
+	contributions := db.Collection("contributions")
+
+	_, err := contributions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "user_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+	})
+	return err
+}
+
+func ensureIdempotencyIndexes(ctx context.Context, db *mongo.Database) error {
+	idempotencyKeys := db.Collection("idempotency_keys")
+
+	_, err := idempotencyKeys.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60),
+		},
+	})
+	return err
+}
+
+func ensureAuditIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("event_audit").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "at", Value: -1}},
+	})
+	return err
+}
+
+func ensureWebhookIndexes(ctx context.Context, db *mongo.Database) error {
+	if _, err := db.Collection("webhooks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "events", Value: 1}},
+	}); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("webhook_deliveries").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_retry_at", Value: 1}},
+		},
+	})
+	return err
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookEndpoint is an outbound HTTP endpoint a user has registered to
+// receive event lifecycle notifications.
+type WebhookEndpoint struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	URL       string             `bson:"url" json:"url"`
+	Secret    string             `bson:"secret" json:"-"`
+	Events    []string           `bson:"events" json:"events"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Subscribes reports whether the endpoint has registered interest in eventName.
+func (w WebhookEndpoint) Subscribes(eventName string) bool {
+	for _, e := range w.Events {
+		if e == eventName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookDeliveryStatus is the lifecycle state of one queued delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryInFlight  WebhookDeliveryStatus = "in_flight"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDeliveryAttempt records the outcome of one POST attempt.
+type WebhookDeliveryAttempt struct {
+	Attempt         int       `bson:"attempt" json:"attempt"`
+	StatusCode      int       `bson:"status_code" json:"status_code"`
+	ResponseSnippet string    `bson:"response_snippet" json:"response_snippet"`
+	Error           string    `bson:"error,omitempty" json:"error,omitempty"`
+	At              time.Time `bson:"at" json:"at"`
+}
+
+// WebhookDelivery is one queued (and retried) notification for a
+// registered webhook endpoint.
+type WebhookDelivery struct {
+	ID          primitive.ObjectID       `bson:"_id" json:"id"`
+	WebhookID   primitive.ObjectID       `bson:"webhook_id" json:"webhook_id"`
+	UserID      primitive.ObjectID       `bson:"user_id" json:"user_id"`
+	Event       string                   `bson:"event" json:"event"`
+	Payload     []byte                   `bson:"payload" json:"payload"`
+	Status      WebhookDeliveryStatus    `bson:"status" json:"status"`
+	Attempts    []WebhookDeliveryAttempt `bson:"attempts" json:"attempts"`
+	NextRetryAt *time.Time               `bson:"next_retry_at,omitempty" json:"next_retry_at,omitempty"`
+	CreatedAt   time.Time                `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,194 @@
+// Package repo holds the data-access layer for subsystems substantial
+// enough to outgrow inline cfg.MongoClient.Database(...).Collection(...)
+// calls in their controllers.
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+)
+
+// ContributionsRepo is the data-access layer for the contributions
+// collection and the summary aggregation built on top of it.
+type ContributionsRepo struct {
+	col *mongo.Collection
+}
+
+// NewContributionsRepo returns a repo backed by db's "contributions" collection.
+func NewContributionsRepo(db *mongo.Database) *ContributionsRepo {
+	return &ContributionsRepo{col: db.Collection("contributions")}
+}
+
+// Create inserts a contribution.
+func (r *ContributionsRepo) Create(ctx context.Context, c models.Contribution) error {
+	_, err := r.col.InsertOne(ctx, c)
+	return err
+}
+
+// ListByEvent returns every contribution posted against eventID, most
+// recent first.
+func (r *ContributionsRepo) ListByEvent(ctx context.Context, eventID, userID primitive.ObjectID) ([]models.Contribution, error) {
+	cur, err := r.col.Find(ctx,
+		bson.M{"event_id": eventID, "user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var contributions []models.Contribution
+	if err := cur.All(ctx, &contributions); err != nil {
+		return nil, err
+	}
+	return contributions, nil
+}
+
+// Delete removes a single contribution owned by userID.
+func (r *ContributionsRepo) Delete(ctx context.Context, id, eventID, userID primitive.ObjectID) (int64, error) {
+	res, err := r.col.DeleteOne(ctx, bson.M{"_id": id, "event_id": eventID, "user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// Summary runs the $match/$group/$project/$bucket pipeline backing
+// GET /events/:id/summary: total contributed, count, percent of
+// targetAmount reached, and a weekly histogram.
+func (r *ContributionsRepo) Summary(ctx context.Context, eventID, userID primitive.ObjectID, targetAmount float64) (models.EventSummary, error) {
+	var summary models.EventSummary
+
+	totals, err := r.aggregateTotals(ctx, eventID, userID, targetAmount)
+	if err != nil {
+		return summary, err
+	}
+	summary = totals
+
+	weekly, err := r.aggregateWeekly(ctx, eventID, userID)
+	if err != nil {
+		return summary, err
+	}
+	summary.Weekly = weekly
+
+	return summary, nil
+}
+
+func (r *ContributionsRepo) aggregateTotals(ctx context.Context, eventID, userID primitive.ObjectID, targetAmount float64) (models.EventSummary, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "event_id", Value: eventID}, {Key: "user_id", Value: userID}}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: "$amount"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "total", Value: 1},
+			{Key: "count", Value: 1},
+			{Key: "percent_complete", Value: percentCompleteExpr(targetAmount)},
+		}}},
+	}
+
+	cur, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return models.EventSummary{}, err
+	}
+	defer cur.Close(ctx)
+
+	var rows []struct {
+		Total           float64 `bson:"total"`
+		Count           int64   `bson:"count"`
+		PercentComplete float64 `bson:"percent_complete"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return models.EventSummary{}, err
+	}
+	if len(rows) == 0 {
+		return models.EventSummary{}, nil
+	}
+
+	return models.EventSummary{
+		Total:           rows[0].Total,
+		Count:           rows[0].Count,
+		PercentComplete: rows[0].PercentComplete,
+	}, nil
+}
+
+func (r *ContributionsRepo) aggregateWeekly(ctx context.Context, eventID, userID primitive.ObjectID) ([]models.WeeklyBucket, error) {
+	now := time.Now().UTC()
+	boundaries := weekBoundaries(now, 12)
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "event_id", Value: eventID}, {Key: "user_id", Value: userID}}}},
+		bson.D{{Key: "$bucket", Value: bson.D{
+			{Key: "groupBy", Value: "$created_at"},
+			{Key: "boundaries", Value: boundaries},
+			{Key: "default", Value: "older"},
+			{Key: "output", Value: bson.D{
+				{Key: "total", Value: bson.D{{Key: "$sum", Value: "$amount"}}},
+				{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			}},
+		}}},
+	}
+
+	cur, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var rows []struct {
+		WeekStart interface{} `bson:"_id"`
+		Total     float64     `bson:"total"`
+		Count     int64       `bson:"count"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	weekly := make([]models.WeeklyBucket, 0, len(rows))
+	for _, row := range rows {
+		var weekStart time.Time
+		switch v := row.WeekStart.(type) {
+		case primitive.DateTime: // how a BSON date decodes into interface{}
+			weekStart = v.Time().UTC()
+		case time.Time:
+			weekStart = v
+		default:
+			continue // the "older" catch-all bucket
+		}
+		weekly = append(weekly, models.WeeklyBucket{WeekStart: weekStart, Total: row.Total, Count: row.Count})
+	}
+	return weekly, nil
+}
+
+// percentCompleteExpr builds the aggregation expression for total/target*100,
+// guarding against a zero target instead of letting Mongo divide by zero.
+func percentCompleteExpr(targetAmount float64) bson.D {
+	if targetAmount <= 0 {
+		return bson.D{{Key: "$literal", Value: 0.0}}
+	}
+	return bson.D{{Key: "$multiply", Value: bson.A{
+		bson.D{{Key: "$divide", Value: bson.A{"$total", targetAmount}}},
+		100,
+	}}}
+}
+
+// weekBoundaries returns n+1 ascending week-start timestamps ending at
+// the start of *next* week, for use as $bucket boundaries. $bucket
+// ranges are [lower, upper), so the final boundary has to be past the
+// end of the current week or every contribution made during it would
+// fall outside all n real buckets and into the discarded "older" default.
+func weekBoundaries(now time.Time, weeks int) []time.Time {
+	nextWeekStart := now.Truncate(7 * 24 * time.Hour).AddDate(0, 0, 7)
+	boundaries := make([]time.Time, weeks+1)
+	for i := range boundaries {
+		boundaries[i] = nextWeekStart.AddDate(0, 0, -7*(weeks-i))
+	}
+	return boundaries
+}
@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekBoundariesCoversCurrentWeek(t *testing.T) {
+	now := time.Date(2026, time.July, 25, 14, 30, 0, 0, time.UTC)
+	boundaries := weekBoundaries(now, 12)
+
+	if len(boundaries) != 13 {
+		t.Fatalf("expected 13 boundaries for 12 weeks, got %d", len(boundaries))
+	}
+	for i := 1; i < len(boundaries); i++ {
+		if !boundaries[i].After(boundaries[i-1]) {
+			t.Fatalf("boundaries must be strictly ascending, got %v then %v", boundaries[i-1], boundaries[i])
+		}
+	}
+
+	last := boundaries[len(boundaries)-1]
+	if !last.After(now) {
+		t.Fatalf("expected the final boundary to be past now (%v) so $bucket's [lower, upper) range includes the current week, got %v", now, last)
+	}
+
+	secondToLast := boundaries[len(boundaries)-2]
+	if now.Before(secondToLast) || !now.Before(last) {
+		t.Fatalf("expected now (%v) to fall inside the last bucket [%v, %v)", now, secondToLast, last)
+	}
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+)
+
+// EventAuditRepo is the append-only data-access layer for event_audit.
+type EventAuditRepo struct {
+	col *mongo.Collection
+}
+
+// NewEventAuditRepo returns a repo backed by db's "event_audit" collection.
+func NewEventAuditRepo(db *mongo.Database) *EventAuditRepo {
+	return &EventAuditRepo{col: db.Collection("event_audit")}
+}
+
+// Record appends one audit entry. It's fire-and-forget from the
+// caller's perspective: a failure to record shouldn't fail the mutation
+// that triggered it, so callers typically ignore the error or just log it.
+func (r *EventAuditRepo) Record(ctx context.Context, entry models.EventAudit) error {
+	entry.ID = primitive.NewObjectID()
+	_, err := r.col.InsertOne(ctx, entry)
+	return err
+}
+
+// ListByEvent returns every audit entry for eventID, most recent first.
+func (r *EventAuditRepo) ListByEvent(ctx context.Context, eventID, userID primitive.ObjectID) ([]models.EventAudit, error) {
+	cur, err := r.col.Find(ctx,
+		bson.M{"event_id": eventID, "user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var entries []models.EventAudit
+	if err := cur.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
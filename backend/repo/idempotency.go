@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IdempotencyRecord is a previously-served response, replayed verbatim
+// when the same Idempotency-Key shows up again for the same user. A
+// record with StatusCode 0 is a reservation: the underlying write is
+// still in flight and hasn't been completed yet.
+type IdempotencyRecord struct {
+	UserID       primitive.ObjectID `bson:"user_id"`
+	Key          string             `bson:"key"`
+	StatusCode   int                `bson:"status_code"`
+	ResponseBody []byte             `bson:"response_body"`
+	CreatedAt    time.Time          `bson:"created_at"`
+}
+
+// Pending reports whether rec is a reservation whose response hasn't
+// been completed yet.
+func (rec IdempotencyRecord) Pending() bool {
+	return rec.StatusCode == 0
+}
+
+// IdempotencyStore is a short-lived, TTL-backed record of responses
+// already served for a given Idempotency-Key, so a mobile client
+// retrying a flaky POST never double-books a contribution.
+type IdempotencyStore struct {
+	col *mongo.Collection
+}
+
+// NewIdempotencyStore returns a store backed by db's "idempotency_keys" collection.
+func NewIdempotencyStore(db *mongo.Database) *IdempotencyStore {
+	return &IdempotencyStore{col: db.Collection("idempotency_keys")}
+}
+
+// Get returns the previously stored response for userID+key, if any.
+func (s *IdempotencyStore) Get(ctx context.Context, userID primitive.ObjectID, key string) (*IdempotencyRecord, error) {
+	var rec IdempotencyRecord
+	err := s.col.FindOne(ctx, bson.M{"user_id": userID, "key": key}).Decode(&rec)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Reserve atomically claims userID+key ahead of doing the underlying
+// write, via the unique user_id+key index: exactly one caller's insert
+// succeeds, closing the check-then-act race that a Get followed by a
+// later write of the response would leave open. The
+// caller must only proceed to create the contribution when reserved is
+// true; a false result means another request (in flight or already
+// completed) holds the key.
+func (s *IdempotencyStore) Reserve(ctx context.Context, userID primitive.ObjectID, key string) (reserved bool, err error) {
+	_, err = s.col.InsertOne(ctx, IdempotencyRecord{UserID: userID, Key: key, CreatedAt: time.Now()})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Complete fills in the response for a reservation made by Reserve, so
+// later retries replay it instead of finding a pending record.
+func (s *IdempotencyStore) Complete(ctx context.Context, userID primitive.ObjectID, key string, statusCode int, body []byte) error {
+	_, err := s.col.UpdateOne(ctx,
+		bson.M{"user_id": userID, "key": key},
+		bson.M{"$set": bson.M{"status_code": statusCode, "response_body": body}},
+	)
+	return err
+}
+
+// Release drops a reservation made by Reserve without completing it, so
+// a request that fails before writing anything (bad input, an unowned
+// event) doesn't block a retry with the same key for the rest of the
+// TTL window.
+func (s *IdempotencyStore) Release(ctx context.Context, userID primitive.ObjectID, key string) error {
+	_, err := s.col.DeleteOne(ctx, bson.M{"user_id": userID, "key": key, "status_code": 0})
+	return err
+}
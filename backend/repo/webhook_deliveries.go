@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+)
+
+// DeliveriesRepo is the data-access layer backing the webhook delivery
+// worker pool: enqueueing, claiming, and recording attempt outcomes.
+type DeliveriesRepo struct {
+	col *mongo.Collection
+}
+
+// NewDeliveriesRepo returns a repo backed by db's "webhook_deliveries" collection.
+func NewDeliveriesRepo(db *mongo.Database) *DeliveriesRepo {
+	return &DeliveriesRepo{col: db.Collection("webhook_deliveries")}
+}
+
+// Enqueue inserts a pending delivery.
+func (r *DeliveriesRepo) Enqueue(ctx context.Context, d models.WebhookDelivery) error {
+	_, err := r.col.InsertOne(ctx, d)
+	return err
+}
+
+// Get returns a delivery by id, scoped to userID.
+func (r *DeliveriesRepo) Get(ctx context.Context, id, userID primitive.ObjectID) (models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := r.col.FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&d)
+	return d, err
+}
+
+// ListByWebhook returns every delivery attempted for webhookID, most
+// recent first.
+func (r *DeliveriesRepo) ListByWebhook(ctx context.Context, webhookID, userID primitive.ObjectID) ([]models.WebhookDelivery, error) {
+	cur, err := r.col.Find(ctx,
+		bson.M{"webhook_id": webhookID, "user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []models.WebhookDelivery
+	if err := cur.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimNext atomically picks up one delivery that's due (pending, with
+// next_retry_at unset or in the past) and marks it in_flight so another
+// worker in the pool won't also pick it up. It returns (zero, false, nil)
+// when there's nothing to do.
+func (r *DeliveriesRepo) ClaimNext(ctx context.Context, leaseFor time.Duration) (models.WebhookDelivery, bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"status": models.WebhookDeliveryPending,
+		"$or": []bson.M{
+			{"next_retry_at": bson.M{"$exists": false}},
+			{"next_retry_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":        models.WebhookDeliveryInFlight,
+		"next_retry_at": now.Add(leaseFor),
+	}}
+
+	var d models.WebhookDelivery
+	err := r.col.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetReturnDocument(options.After),
+	).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return models.WebhookDelivery{}, false, nil
+	}
+	if err != nil {
+		return models.WebhookDelivery{}, false, err
+	}
+	return d, true, nil
+}
+
+// RecordAttempt appends attempt to the delivery and moves it to its next
+// state: succeeded, retryable (back to pending with nextRetryAt), or
+// permanently failed once attempts are exhausted.
+func (r *DeliveriesRepo) RecordAttempt(ctx context.Context, id primitive.ObjectID, attempt models.WebhookDeliveryAttempt, succeeded bool, nextRetryAt *time.Time) error {
+	status := models.WebhookDeliveryFailed
+	switch {
+	case succeeded:
+		status = models.WebhookDeliverySucceeded
+	case nextRetryAt != nil:
+		status = models.WebhookDeliveryPending
+	}
+
+	set := bson.M{"status": status}
+	if nextRetryAt != nil {
+		set["next_retry_at"] = *nextRetryAt
+	} else {
+		set["next_retry_at"] = nil
+	}
+
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$push": bson.M{"attempts": attempt}, "$set": set},
+	)
+	return err
+}
+
+// Redeliver resets a delivery back to pending so the worker pool picks
+// it up again on the next poll, regardless of its previous outcome.
+func (r *DeliveriesRepo) Redeliver(ctx context.Context, id, userID primitive.ObjectID) (int64, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "user_id": userID},
+		bson.M{
+			"$set":   bson.M{"status": models.WebhookDeliveryPending},
+			"$unset": bson.M{"next_retry_at": ""},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.MatchedCount, nil
+}
@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	models "github.com/phillip/contribution-tracker-go/models"
+)
+
+// WebhooksRepo is the data-access layer for registered webhook endpoints.
+type WebhooksRepo struct {
+	col *mongo.Collection
+}
+
+// NewWebhooksRepo returns a repo backed by db's "webhooks" collection.
+func NewWebhooksRepo(db *mongo.Database) *WebhooksRepo {
+	return &WebhooksRepo{col: db.Collection("webhooks")}
+}
+
+// Create inserts a webhook endpoint.
+func (r *WebhooksRepo) Create(ctx context.Context, w models.WebhookEndpoint) error {
+	_, err := r.col.InsertOne(ctx, w)
+	return err
+}
+
+// Get returns the webhook endpoint by id, scoped to userID.
+func (r *WebhooksRepo) Get(ctx context.Context, id, userID primitive.ObjectID) (models.WebhookEndpoint, error) {
+	var w models.WebhookEndpoint
+	err := r.col.FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&w)
+	return w, err
+}
+
+// ListSubscribers returns every webhook endpoint belonging to userID
+// that has registered interest in eventName.
+func (r *WebhooksRepo) ListSubscribers(ctx context.Context, userID primitive.ObjectID, eventName string) ([]models.WebhookEndpoint, error) {
+	cur, err := r.col.Find(ctx, bson.M{"user_id": userID, "events": eventName})
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []models.WebhookEndpoint
+	if err := cur.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cursor is the decoded form of the opaque, base64-encoded keyset
+// pagination token returned as next_cursor and accepted back via
+// ?cursor=. SortValue is the string form of whatever field the page was
+// sorted on (updated_at, deadline, target_amount, ...); ID breaks ties
+// between documents that share a SortValue.
+type Cursor struct {
+	SortValue string             `json:"sv"`
+	ID        primitive.ObjectID `json:"id"`
+}
+
+// EncodeCursor serializes a Cursor into the opaque token clients pass
+// back as ?cursor=.
+func EncodeCursor(c Cursor) (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor parses a token previously returned by EncodeCursor. A
+// malformed token is the caller's problem to report as a 400, not ours
+// to guess at.
+func DecodeCursor(token string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, fmt.Errorf("invalid cursor payload: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GenerateETag derives a strong entity tag from a document's id and its
+// last-modified timestamp, so any write that bumps updated_at also
+// invalidates caches and optimistic-concurrency checks keyed on it.
+func GenerateETag(id primitive.ObjectID, updatedAt time.Time) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s:%d", id.Hex(), updatedAt.UnixNano())))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}